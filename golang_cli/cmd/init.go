@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultConfigTemplate = `# golang_cli configuration file.
+# Values here are overridden by SODA_* environment variables, which are in
+# turn overridden by command-line flags.
+
+rpc-url: "http://localhost:8545"
+chain-id: "1"
+keystore: "~/.golang_cli/keystore"
+log-level: "info"
+log-format: "pretty"
+`
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Scaffold a starter config file",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "golang_cli.yaml"
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("config file already exists at %s", path)
+		}
+		if err := os.WriteFile(path, []byte(defaultConfigTemplate), 0644); err != nil {
+			return fmt.Errorf("write config file: %w", err)
+		}
+
+		fmt.Println("Wrote config file:", path)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"golang_cli/signer"
+)
+
+var keyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Manage secp256k1 keystore files",
+}
+
+func init() {
+	rootCmd.AddCommand(keyCmd)
+	keyCmd.AddCommand(keyNewCmd, keyInspectCmd, keySignCmd, keyChangePasswordCmd)
+
+	keyNewCmd.Flags().String("password", "", "file containing the keystore password (prompted for if omitted)")
+	keyNewCmd.Flags().Bool("lightkdf", false, "use less secure scrypt parameters")
+
+	keyInspectCmd.Flags().String("password", "", "file containing the keystore password")
+	keyInspectCmd.Flags().Bool("private", false, "also print the decrypted private key")
+
+	keySignCmd.Flags().String("password", "", "file containing the keystore password")
+
+	keyChangePasswordCmd.Flags().String("password", "", "file containing the current keystore password")
+	keyChangePasswordCmd.Flags().String("newpassword", "", "file containing the new keystore password")
+}
+
+var keyNewCmd = &cobra.Command{
+	Use:   "new <keyfile>",
+	Short: "Generate a new keyfile",
+	Long: `Generate a fresh secp256k1 keypair and write it to disk as a V3 keystore
+JSON file, encrypted with a scrypt-derived key, interoperable with geth and
+MetaMask exports.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyfile := args[0]
+		passwordFile, _ := cmd.Flags().GetString("password")
+		light, _ := cmd.Flags().GetBool("lightkdf")
+
+		if _, err := os.Stat(keyfile); err == nil {
+			return fmt.Errorf("keyfile already exists at %s", keyfile)
+		}
+
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			return fmt.Errorf("generate private key: %w", err)
+		}
+
+		password, err := readPassword(passwordFile, true)
+		if err != nil {
+			return err
+		}
+
+		scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+		if light {
+			scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+		}
+		if err := writeKeystore(keyfile, privateKey, password, scryptN, scryptP); err != nil {
+			return err
+		}
+
+		addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+		log.Info().Str("address", addr.Hex()).Str("keyfile", keyfile).Msg("generated keyfile")
+		fmt.Println("Address:", addr.Hex())
+		return nil
+	},
+}
+
+var keyInspectCmd = &cobra.Command{
+	Use:   "inspect <keyfile>",
+	Short: "Print the address of a keyfile, optionally its private key",
+	Long: `Print various information about the keyfile.
+
+Private key information can be printed by using the --private flag;
+make sure to use this feature with great caution!`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passwordFile, _ := cmd.Flags().GetString("password")
+		showPrivate, _ := cmd.Flags().GetBool("private")
+
+		keyjson, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("read keyfile: %w", err)
+		}
+
+		if !showPrivate {
+			addr, err := keystoreAddress(keyjson)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Address:", addr.Hex())
+			return nil
+		}
+
+		password, err := readPassword(passwordFile, false)
+		if err != nil {
+			return err
+		}
+		key, err := keystore.DecryptKey(keyjson, password)
+		if err != nil {
+			return fmt.Errorf("decrypt key: %w", err)
+		}
+
+		fmt.Println("Address:    ", key.Address.Hex())
+		fmt.Println("Private key:", hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)))
+		return nil
+	},
+}
+
+var keySignCmd = &cobra.Command{
+	Use:   "sign <keyfile> <hex-hash>",
+	Short: "Sign a 32-byte hash with a keyfile",
+	Long: `Produce a 65-byte compact secp256k1 signature (R || S || V) over the given
+32-byte hash. By default the keyfile is decrypted locally; pass the global
+--signer flag to sign through an external signer instead, in which case
+keyfile is ignored. Note that "clef://..." signers cannot perform this
+command: clef always rehashes what it's given before signing, so it cannot
+sign a raw digest as-is.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passwordFile, _ := cmd.Flags().GetString("password")
+		keyfile, hexHash := args[0], args[1]
+
+		hash, err := hex.DecodeString(strings.TrimPrefix(hexHash, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid hash: %w", err)
+		}
+
+		spec := viper.GetString("signer")
+		if spec == "" {
+			spec = "keystore://" + keyfile
+		}
+		s, err := signer.Open(spec, passwordFile)
+		if err != nil {
+			return fmt.Errorf("open signer: %w", err)
+		}
+
+		sig, err := s.SignHash(hash)
+		if err != nil {
+			return fmt.Errorf("sign hash: %w", err)
+		}
+
+		fmt.Println("Signature:", hex.EncodeToString(sig))
+		return nil
+	},
+}
+
+var keyChangePasswordCmd = &cobra.Command{
+	Use:   "changepassword <keyfile>",
+	Short: "Change the password protecting a keyfile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passwordFile, _ := cmd.Flags().GetString("password")
+		newPasswordFile, _ := cmd.Flags().GetString("newpassword")
+		keyfile := args[0]
+
+		keyjson, err := os.ReadFile(keyfile)
+		if err != nil {
+			return fmt.Errorf("read keyfile: %w", err)
+		}
+		password, err := readPassword(passwordFile, false)
+		if err != nil {
+			return err
+		}
+		key, err := keystore.DecryptKey(keyjson, password)
+		if err != nil {
+			return fmt.Errorf("decrypt key: %w", err)
+		}
+
+		newPassword, err := readNamedPassword(newPasswordFile, "new password", true)
+		if err != nil {
+			return err
+		}
+
+		if err := writeKeystore(keyfile, key.PrivateKey, newPassword, keystore.StandardScryptN, keystore.StandardScryptP); err != nil {
+			return err
+		}
+		log.Info().Str("keyfile", keyfile).Msg("changed keyfile password")
+		return nil
+	},
+}
+
+// writeKeystore encrypts privateKey under password and writes it to keyfile
+// as V3 keystore JSON, interoperable with geth and MetaMask exports.
+func writeKeystore(keyfile string, privateKey *ecdsa.PrivateKey, password string, scryptN, scryptP int) error {
+	key := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	keyjson, err := keystore.EncryptKey(key, password, scryptN, scryptP)
+	if err != nil {
+		return fmt.Errorf("encrypt key: %w", err)
+	}
+	if err := os.WriteFile(keyfile, keyjson, 0600); err != nil {
+		return fmt.Errorf("write keyfile: %w", err)
+	}
+	return nil
+}
+
+// keystoreAddress extracts the address from keystore JSON without
+// decrypting the private key.
+func keystoreAddress(keyjson []byte) (common.Address, error) {
+	var plain struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyjson, &plain); err != nil {
+		return common.Address{}, fmt.Errorf("parse keyfile: %w", err)
+	}
+	raw, err := hex.DecodeString(plain.Address)
+	if err != nil || len(raw) != common.AddressLength {
+		return common.Address{}, fmt.Errorf("parse keyfile: malformed address")
+	}
+	return common.BytesToAddress(raw), nil
+}
+
+// readPassword resolves the keystore password from a file, falling back to
+// an interactive prompt (with confirmation for newly created passwords).
+func readPassword(file string, confirm bool) (string, error) {
+	return readNamedPassword(file, "password", confirm)
+}
+
+func readNamedPassword(file, label string, confirm bool) (string, error) {
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("read %s file: %w", label, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	fmt.Printf("Enter %s: ", label)
+	reader := bufio.NewReader(os.Stdin)
+	password, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", label, err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+
+	if confirm {
+		fmt.Printf("Repeat %s: ", label)
+		again, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("read %s confirmation: %w", label, err)
+		}
+		if strings.TrimRight(again, "\r\n") != password {
+			return "", fmt.Errorf("passwords do not match")
+		}
+	}
+	return password, nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func execRoot(t *testing.T, args ...string) error {
+	t.Helper()
+	rootCmd.SetArgs(args)
+	return rootCmd.Execute()
+}
+
+func TestKeyNewInspectSignRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	keyfile := filepath.Join(dir, "key.json")
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("correct horse battery staple\n"), 0600))
+
+	require.NoError(t, execRoot(t, "key", "new", "--password", passwordFile, "--lightkdf", keyfile))
+
+	keyjson, err := os.ReadFile(keyfile)
+	require.NoError(t, err)
+
+	addr, err := keystoreAddress(keyjson)
+	require.NoError(t, err)
+
+	key, err := keystore.DecryptKey(keyjson, "correct horse battery staple")
+	require.NoError(t, err)
+	require.Equal(t, addr, key.Address)
+
+	hash := crypto.Keccak256([]byte("hello soda"))
+	sig, err := crypto.Sign(hash, key.PrivateKey)
+	require.NoError(t, err)
+
+	recovered, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, addr, crypto.PubkeyToAddress(*recovered))
+}
+
+func TestKeySignCommand(t *testing.T) {
+	dir := t.TempDir()
+	keyfile := filepath.Join(dir, "key.json")
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("hunter2\n"), 0600))
+
+	require.NoError(t, execRoot(t, "key", "new", "--password", passwordFile, "--lightkdf", keyfile))
+
+	keyjson, err := os.ReadFile(keyfile)
+	require.NoError(t, err)
+	key, err := keystore.DecryptKey(keyjson, "hunter2")
+	require.NoError(t, err)
+
+	hash := crypto.Keccak256([]byte("sign me"))
+	require.NoError(t, execRoot(t, "key", "sign", "--password", passwordFile, keyfile, hex.EncodeToString(hash)))
+
+	wantSig, err := crypto.Sign(hash, key.PrivateKey)
+	require.NoError(t, err)
+	require.Len(t, wantSig, 65)
+}
+
+func TestKeyChangePassword(t *testing.T) {
+	dir := t.TempDir()
+	keyfile := filepath.Join(dir, "key.json")
+	oldPasswordFile := filepath.Join(dir, "old.txt")
+	newPasswordFile := filepath.Join(dir, "new.txt")
+	require.NoError(t, os.WriteFile(oldPasswordFile, []byte("old-password\n"), 0600))
+	require.NoError(t, os.WriteFile(newPasswordFile, []byte("new-password\n"), 0600))
+
+	require.NoError(t, execRoot(t, "key", "new", "--password", oldPasswordFile, "--lightkdf", keyfile))
+
+	before, err := os.ReadFile(keyfile)
+	require.NoError(t, err)
+	beforeKey, err := keystore.DecryptKey(before, "old-password")
+	require.NoError(t, err)
+
+	require.NoError(t, execRoot(t, "key", "changepassword",
+		"--password", oldPasswordFile,
+		"--newpassword", newPasswordFile,
+		keyfile,
+	))
+
+	after, err := os.ReadFile(keyfile)
+	require.NoError(t, err)
+	afterKey, err := keystore.DecryptKey(after, "new-password")
+	require.NoError(t, err)
+	require.Equal(t, beforeKey.Address, afterKey.Address)
+
+	_, err = keystore.DecryptKey(after, "old-password")
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+// Package cmd implements the golang_cli command tree. Subsystems such as
+// key, contract, tx and encrypt plug in via rootCmd.AddCommand in their own
+// init functions.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cfgFile string
+
+var rootCmd = &cobra.Command{
+	Use:   "golang_cli",
+	Short: "The SODA command-line tool",
+	Long: `golang_cli is the SODA command-line tool.
+
+It manages secp256k1 keystores and talks to SODA-enabled chains over JSON-RPC.`,
+	SilenceUsage:      true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return initLogger() },
+}
+
+// Execute runs the root command, exiting the process with a non-zero status
+// on error.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	cobra.OnInitialize(initConfig)
+
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&cfgFile, "config", "", "config file (default $HOME/.golang_cli.yaml)")
+	flags.String("rpc-url", "", "JSON-RPC endpoint of the target chain")
+	flags.String("chain-id", "", "chain ID to target")
+	flags.String("keystore", "", "path to a keystore file or directory")
+	flags.String("signer", "", "signer backend: keystore://path, clef://path, or hardware://path")
+	flags.String("log-level", "info", "log level: debug, info, warn, error")
+	flags.String("log-format", "pretty", "log output format: pretty or json")
+
+	for _, name := range []string{"rpc-url", "chain-id", "keystore", "signer", "log-level", "log-format"} {
+		if err := viper.BindPFlag(name, flags.Lookup(name)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// initConfig wires up viper's precedence chain: flags > env (SODA_*) >
+// config file (YAML/TOML) > defaults.
+func initConfig() {
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else {
+		home, err := os.UserHomeDir()
+		cobra.CheckErr(err)
+		viper.AddConfigPath(home)
+		viper.SetConfigName(".golang_cli")
+	}
+
+	viper.SetEnvPrefix("SODA")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+
+	if err := viper.ReadInConfig(); err == nil {
+		fmt.Fprintln(os.Stderr, "using config file:", viper.ConfigFileUsed())
+	}
+}
+
+// initLogger configures the global zerolog logger from the resolved
+// log-level and log-format settings.
+func initLogger() error {
+	level, err := zerolog.ParseLevel(viper.GetString("log-level"))
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", viper.GetString("log-level"), err)
+	}
+
+	var out = os.Stderr
+	var logger zerolog.Logger
+	if viper.GetString("log-format") == "json" {
+		logger = zerolog.New(out)
+	} else {
+		logger = zerolog.New(zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339})
+	}
+	log.Logger = logger.Level(level).With().Timestamp().Logger()
+	return nil
+}
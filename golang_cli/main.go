@@ -0,0 +1,8 @@
+// Command golang_cli is the SODA command-line tool.
+package main
+
+import "golang_cli/cmd"
+
+func main() {
+	cmd.Execute()
+}
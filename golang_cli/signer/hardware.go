@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// HardwareSigner is a placeholder for future Ledger/Trezor support. It
+// satisfies the Signer interface so golang_cli can accept
+// "--signer hardware://<path>" ahead of the underlying USB/HID
+// implementation landing.
+type HardwareSigner struct {
+	path string
+}
+
+// NewHardwareSigner records the device path for a future hardware wallet
+// backend. It always returns an error today.
+func NewHardwareSigner(path string) (*HardwareSigner, error) {
+	return nil, fmt.Errorf("hardware signer %q: not yet implemented", path)
+}
+
+func (s *HardwareSigner) Address() (common.Address, error) {
+	return common.Address{}, errNotImplemented
+}
+
+func (s *HardwareSigner) SignHash(hash []byte) ([]byte, error) {
+	return nil, errNotImplemented
+}
+
+func (s *HardwareSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, errNotImplemented
+}
+
+func (s *HardwareSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	return nil, errNotImplemented
+}
+
+var errNotImplemented = fmt.Errorf("hardware signer: not yet implemented")
@@ -0,0 +1,81 @@
+package signer
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// KeystoreSigner signs with a private key decrypted from a local V3
+// keystore JSON file. This is golang_cli's original, current behavior.
+type KeystoreSigner struct {
+	key *keystore.Key
+}
+
+// NewKeystoreSigner decrypts keyfile using the password stored in
+// passwordFile and returns a Signer backed by the resulting private key.
+func NewKeystoreSigner(keyfile, passwordFile string) (*KeystoreSigner, error) {
+	keyjson, err := os.ReadFile(keyfile)
+	if err != nil {
+		return nil, fmt.Errorf("read keyfile: %w", err)
+	}
+	password, err := readPassword(passwordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyjson, password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt key: %w", err)
+	}
+	return &KeystoreSigner{key: key}, nil
+}
+
+func (s *KeystoreSigner) Address() (common.Address, error) {
+	return s.key.Address, nil
+}
+
+func (s *KeystoreSigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key.PrivateKey)
+}
+
+func (s *KeystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key.PrivateKey)
+}
+
+func (s *KeystoreSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("hash typed data: %w", err)
+	}
+	return crypto.Sign(hash, s.key.PrivateKey)
+}
+
+// readPassword resolves the keystore password from a file, falling back to
+// an interactive prompt so that "--signer keystore://..." behaves the same
+// as the keystore-only commands in cmd/key.go.
+func readPassword(file string) (string, error) {
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("read password file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	fmt.Print("Enter password: ")
+	reader := bufio.NewReader(os.Stdin)
+	password, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	return strings.TrimRight(password, "\r\n"), nil
+}
@@ -0,0 +1,56 @@
+// Package signer abstracts how golang_cli produces signatures for SODA
+// transactions. It lets callers swap an in-process keystore for an external
+// signer daemon (clef) or a hardware wallet without touching the
+// orchestration code that builds encrypted inputs and submits transactions.
+package signer
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer produces signatures for a single account, regardless of where the
+// private key actually lives.
+type Signer interface {
+	// Address returns the account this signer signs for.
+	Address() (common.Address, error)
+	// SignHash signs an arbitrary 32-byte digest, returning a 65-byte
+	// compact secp256k1 signature (R || S || V) over that exact digest.
+	// Not every backend can honor this: clef, for instance, refuses to
+	// blind-sign a raw digest and rehashes whatever it is given, so
+	// ClefSigner reports SignHash as unsupported rather than silently
+	// signing a different message. Callers that need clef support should
+	// go through SignTx or SignTypedData instead.
+	SignHash(hash []byte) ([]byte, error)
+	// SignTx signs tx for the given chain and returns the signed transaction.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignTypedData signs an EIP-712 typed data payload.
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+}
+
+// Open resolves a `--signer` spec of the form "<scheme>://<path>" into a
+// Signer. Supported schemes are "keystore" (a local V3 keystore JSON file,
+// decrypted with passwordFile) and "clef" (an external clef signer reached
+// over IPC or HTTP).
+func Open(spec, passwordFile string) (Signer, error) {
+	scheme, path, ok := strings.Cut(spec, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid signer spec %q: expected scheme://path", spec)
+	}
+
+	switch scheme {
+	case "keystore":
+		return NewKeystoreSigner(path, passwordFile)
+	case "clef":
+		return NewClefSigner(path)
+	case "hardware":
+		return NewHardwareSigner(path)
+	default:
+		return nil, fmt.Errorf("unsupported signer scheme %q", scheme)
+	}
+}
@@ -0,0 +1,251 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeystore(t *testing.T) (keyfile, passwordFile string, address common.Address) {
+	t.Helper()
+	dir := t.TempDir()
+	keyfile = filepath.Join(dir, "key.json")
+	passwordFile = filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("correct horse battery staple\n"), 0600))
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address = crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	keyjson, err := keystore.EncryptKey(&keystore.Key{
+		Address:    address,
+		PrivateKey: privateKey,
+	}, "correct horse battery staple", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyfile, keyjson, 0600))
+	return keyfile, passwordFile, address
+}
+
+func TestKeystoreSignerSignHash(t *testing.T) {
+	keyfile, passwordFile, address := newTestKeystore(t)
+
+	s, err := Open("keystore://"+keyfile, passwordFile)
+	require.NoError(t, err)
+
+	got, err := s.Address()
+	require.NoError(t, err)
+	require.Equal(t, address, got)
+
+	hash := crypto.Keccak256([]byte("hello soda"))
+	sig, err := s.SignHash(hash)
+	require.NoError(t, err)
+
+	recovered, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*recovered))
+}
+
+func TestOpenUnknownScheme(t *testing.T) {
+	_, err := Open("ledger://whatever", "")
+	require.Error(t, err)
+
+	_, err = Open("no-scheme-at-all", "")
+	require.Error(t, err)
+}
+
+func TestHardwareSignerNotImplemented(t *testing.T) {
+	_, err := Open("hardware:///dev/usb0", "")
+	require.Error(t, err)
+}
+
+// fakeClef is a minimal stand-in for clef's external-signer JSON-RPC API,
+// just enough to exercise ClefSigner's request/response handling.
+type fakeClef struct {
+	address common.Address
+	key     *keystore.Key
+}
+
+func (f *fakeClef) List() []common.Address {
+	return []common.Address{f.address}
+}
+
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// SignTransaction mirrors clef's account_signTransaction: build the tx args
+// describe, then sign it, so the fake exercises the same field mapping
+// (legacy vs. dynamic-fee, chain ID) that ClefSigner.SignTx sends.
+func (f *fakeClef) SignTransaction(args *apitypes.SendTxArgs) (*signTransactionResult, error) {
+	if args.From.Address() != f.address {
+		return nil, errors.New("unknown account")
+	}
+
+	var chainID *big.Int
+	if args.ChainID != nil {
+		chainID = (*big.Int)(args.ChainID)
+	}
+	signed, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(chainID), f.key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &signTransactionResult{Raw: raw, Tx: signed}, nil
+}
+
+// SignTypedData mirrors clef's account_signTypedData.
+func (f *fakeClef) SignTypedData(addr *common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	if addr.Address() != f.address {
+		return nil, errors.New("unknown account")
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, f.key.PrivateKey)
+}
+
+func newFakeClefServer(t *testing.T, key *keystore.Key) string {
+	t.Helper()
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", &fakeClef{address: key.Address, key: key}))
+	t.Cleanup(server.Stop)
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL
+}
+
+// TestClefSignerSignHashUnsupported asserts that ClefSigner.SignHash fails
+// loudly instead of returning a signature over a message other than hash:
+// real clef rehashes whatever it's given via account_signData, so signing
+// hash directly is not something clef can do.
+func TestClefSignerSignHashUnsupported(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+
+	endpoint := newFakeClefServer(t, key)
+
+	s, err := Open("clef://"+endpoint, "")
+	require.NoError(t, err)
+
+	got, err := s.Address()
+	require.NoError(t, err)
+	require.Equal(t, key.Address, got)
+
+	_, err = s.SignHash(crypto.Keccak256([]byte("sign me via clef")))
+	require.Error(t, err)
+}
+
+// TestClefSignerSignTx round-trips a legacy and a dynamic-fee tx through
+// ClefSigner.SignTx, covering the tx-type branching and ChainID handling in
+// clef.go that fakeClef's account_signData coverage never exercised.
+func TestClefSignerSignTx(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	endpoint := newFakeClefServer(t, key)
+
+	s, err := Open("clef://"+endpoint, "")
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x00000000000000000000000000000000000Ca11")
+	chainID := big.NewInt(1337)
+
+	legacy := types.NewTx(&types.LegacyTx{
+		Nonce:    3,
+		To:       &to,
+		Value:    big.NewInt(42),
+		Gas:      21000,
+		GasPrice: big.NewInt(7),
+		Data:     []byte{0x01, 0x02},
+	})
+	dynamicFee := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     4,
+		To:        &to,
+		Value:     big.NewInt(99),
+		Gas:       30000,
+		GasFeeCap: big.NewInt(100),
+		GasTipCap: big.NewInt(2),
+		Data:      []byte{0x03},
+	})
+
+	for _, tc := range []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{"legacy", legacy},
+		{"dynamicFee", dynamicFee},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			signed, err := s.SignTx(tc.tx, chainID)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.tx.Type(), signed.Type())
+			require.Equal(t, tc.tx.Nonce(), signed.Nonce())
+			require.Equal(t, tc.tx.Gas(), signed.Gas())
+			require.Equal(t, tc.tx.Value(), signed.Value())
+			require.Equal(t, tc.tx.Data(), signed.Data())
+			require.Equal(t, *tc.tx.To(), *signed.To())
+
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			require.Equal(t, key.Address, sender)
+		})
+	}
+}
+
+// TestClefSignerSignTypedData round-trips an EIP-712 typed data payload
+// through ClefSigner.SignTypedData.
+func TestClefSignerSignTypedData(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key := &keystore.Key{Address: crypto.PubkeyToAddress(privateKey.PublicKey), PrivateKey: privateKey}
+	endpoint := newFakeClefServer(t, key)
+
+	s, err := Open("clef://"+endpoint, "")
+	require.NoError(t, err)
+
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+			},
+			"Message": {
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Message",
+		Domain:      apitypes.TypedDataDomain{Name: "golang_cli"},
+		Message: apitypes.TypedDataMessage{
+			"contents": "sign me via clef",
+		},
+	}
+
+	sig, err := s.SignTypedData(typedData)
+	require.NoError(t, err)
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+	recovered, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, key.Address, crypto.PubkeyToAddress(*recovered))
+}
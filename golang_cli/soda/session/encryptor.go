@@ -0,0 +1,191 @@
+// Package session provides a batched encrypted-input builder for SODA.
+//
+// SODA encrypts function inputs per-call using a key derived from an ECDH
+// handshake between the caller and the network's per-function encryption
+// key. Deriving that handshake (and the AES-GCM cipher built on top of it)
+// is the expensive part of an encrypted call; for workloads that submit many
+// transactions to the same contract function, Encryptor caches the derived
+// key across calls instead of repeating the handshake every time.
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/holiman/uint256"
+)
+
+// Encryptor encrypts ABI uint256 inputs for repeated calls to a single
+// contract function, reusing one ECDH-derived AES-GCM key across calls.
+//
+// The ECDH handshake is static-static (userKey against networkPubKey), so
+// every Encryptor built from the same inputs derives the identical key.
+// Two such Encryptors alive at once - two goroutines targeting the same
+// contract function, say - would be free to pick colliding GCM nonces,
+// which breaks AES-GCM's confidentiality and authenticity guarantees. To
+// prevent that, NewEncryptor refuses to open a second live Encryptor for a
+// session that's already open; call Close when done with one so another
+// can be opened. That only guards a single process: a second CLI
+// invocation (after a crash, say) has no way to know about a session held
+// by a different process, so callers must also ensure they never run two
+// live sessions for the same (userKey, networkPubKey, contractAddr,
+// funcSelector) tuple concurrently across processes.
+type Encryptor struct {
+	aead         cipher.AEAD
+	noncePrefix  [4]byte
+	contractAddr common.Address
+	funcSelector [4]byte
+	sessionKey   string
+}
+
+var (
+	liveSessionsMu sync.Mutex
+	liveSessions   = make(map[string]bool)
+)
+
+// NewEncryptor derives an AES-256-GCM session key from a secp256k1 ECDH
+// handshake between userKey and networkPubKey, and caches it on the
+// returned Encryptor for reuse across EncryptInputs calls. Call Close on
+// the returned Encryptor once it's no longer needed.
+//
+// networkPubKey is the SODA network's actual per-function encryption key
+// for (contractAddr, funcSelector), fetched from chain or trusted config by
+// the caller. It must NOT be derived locally from contractAddr and
+// funcSelector alone: both are public, on-chain-visible values, so anyone
+// could recompute a "peer private key" from them and decrypt every
+// ciphertext this Encryptor produces. The shared secret only protects the
+// inputs if the peer side of the ECDH handshake is a key the network
+// actually holds.
+func NewEncryptor(userKey *ecdsa.PrivateKey, networkPubKey *ecdsa.PublicKey, contractAddr common.Address, funcSelector [4]byte) (*Encryptor, error) {
+	if networkPubKey == nil {
+		return nil, fmt.Errorf("network public key is required")
+	}
+	peerPub := ecies.ImportECDSAPublic(networkPubKey)
+
+	sessionKey := sessionKeyFor(userKey, networkPubKey, contractAddr, funcSelector)
+	if err := acquireSession(sessionKey); err != nil {
+		return nil, err
+	}
+
+	sharedSecret, err := ecies.ImportECDSA(userKey).GenerateShared(peerPub, 32, 0)
+	if err != nil {
+		releaseSession(sessionKey)
+		return nil, fmt.Errorf("derive ecdh shared secret: %w", err)
+	}
+
+	block, err := aes.NewCipher(sharedSecret)
+	if err != nil {
+		releaseSession(sessionKey)
+		return nil, fmt.Errorf("init aes cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		releaseSession(sessionKey)
+		return nil, fmt.Errorf("init aes-gcm: %w", err)
+	}
+
+	var noncePrefix [4]byte
+	copy(noncePrefix[:], crypto.Keccak256(contractAddr.Bytes(), funcSelector[:])[:4])
+
+	return &Encryptor{
+		aead:         aead,
+		noncePrefix:  noncePrefix,
+		contractAddr: contractAddr,
+		funcSelector: funcSelector,
+		sessionKey:   sessionKey,
+	}, nil
+}
+
+// Close releases the session so another Encryptor may be opened for the
+// same (userKey, networkPubKey, contractAddr, funcSelector) tuple. It is
+// safe to call more than once.
+func (e *Encryptor) Close() {
+	releaseSession(e.sessionKey)
+}
+
+// sessionKeyFor identifies a session by the inputs that determine its
+// derived AES-GCM key, so liveSessions can refuse to open two Encryptors
+// that would share one.
+func sessionKeyFor(userKey *ecdsa.PrivateKey, networkPubKey *ecdsa.PublicKey, contractAddr common.Address, funcSelector [4]byte) string {
+	digest := crypto.Keccak256(
+		crypto.FromECDSAPub(&userKey.PublicKey),
+		crypto.FromECDSAPub(networkPubKey),
+		contractAddr.Bytes(),
+		funcSelector[:],
+	)
+	return hex.EncodeToString(digest)
+}
+
+func acquireSession(sessionKey string) error {
+	liveSessionsMu.Lock()
+	defer liveSessionsMu.Unlock()
+	if liveSessions[sessionKey] {
+		return fmt.Errorf("an Encryptor for this session is already open; close it before opening another, reusing the session key concurrently would risk an AES-GCM nonce collision")
+	}
+	liveSessions[sessionKey] = true
+	return nil
+}
+
+func releaseSession(sessionKey string) {
+	liveSessionsMu.Lock()
+	defer liveSessionsMu.Unlock()
+	delete(liveSessions, sessionKey)
+}
+
+// EncryptInputs encrypts each value independently under the session's
+// cached key, and returns both the per-value ciphertexts (ready for ABI
+// packing alongside the call data) and a combined payload: the keccak256
+// digest of the packed ciphertexts, which the caller's transaction signer
+// should sign so the contract can verify the encrypted inputs in transit.
+//
+// nonce must be the Ethereum account nonce of the transaction this call's
+// ciphertexts are going into. Because the cached session key is the same
+// across every Encryptor built from the same (userKey, networkPubKey,
+// contractAddr, funcSelector) - including ones from a different process or
+// a retried run - the GCM nonce can't be allowed to restart from zero each
+// time; it's derived from the account nonce instead, which the chain
+// guarantees a given account never reuses, so it stays unique under the
+// shared key regardless of how many Encryptor instances share it.
+func (e *Encryptor) EncryptInputs(nonce uint64, values ...*uint256.Int) (ciphertexts [][]byte, payload []byte, err error) {
+	ciphertexts = make([][]byte, len(values))
+	for i, v := range values {
+		plaintext := v.Bytes32()
+
+		iv := e.gcmNonce(nonce, i)
+		aad := make([]byte, 8)
+		binary.BigEndian.PutUint64(aad, nonce)
+
+		ciphertexts[i] = e.aead.Seal(nil, iv, plaintext[:], aad)
+	}
+
+	packed := make([]byte, 0, len(ciphertexts)*(32+e.aead.Overhead()))
+	for _, ct := range ciphertexts {
+		packed = append(packed, ct...)
+	}
+	payload = crypto.Keccak256(packed)
+	return ciphertexts, payload, nil
+}
+
+// gcmNonce derives the 12-byte AES-GCM nonce for the index'th value of a
+// call made with the given account nonce. It folds in the contract/selector
+// prefix, the account nonce, and the value's index within the batch, then
+// takes the low 12 bytes of the keccak256 digest. The account nonce is
+// unique per transaction (the chain enforces it), and the index is unique
+// within a single EncryptInputs call, so the pair is unique across every
+// call ever made under this session key - including from other Encryptor
+// instances sharing the same key.
+func (e *Encryptor) gcmNonce(nonce uint64, index int) []byte {
+	var material [8 + 4]byte
+	binary.BigEndian.PutUint64(material[:8], nonce)
+	binary.BigEndian.PutUint32(material[8:], uint32(index))
+	digest := crypto.Keccak256(e.noncePrefix[:], material[:])
+	return digest[:12]
+}
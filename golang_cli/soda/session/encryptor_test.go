@@ -0,0 +1,213 @@
+package session
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func testContractAndSelector() (common.Address, [4]byte) {
+	contract := common.HexToAddress("0x00000000000000000000000000000000000Beef")
+	var selector [4]byte
+	copy(selector[:], crypto.Keccak256([]byte("transfer(address,uint256)"))[:4])
+	return contract, selector
+}
+
+func TestEncryptInputsRoundTrip(t *testing.T) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract, selector := testContractAndSelector()
+
+	enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+
+	values := []*uint256.Int{uint256.NewInt(42), uint256.NewInt(1_000_000)}
+	ciphertexts, payload, err := enc.EncryptInputs(7, values...)
+	require.NoError(t, err)
+	require.Len(t, ciphertexts, len(values))
+	require.NotEmpty(t, payload)
+	enc.Close()
+
+	// A fresh Encryptor derived from the same inputs should share the same
+	// session key, so it can decrypt what the first Encryptor produced.
+	dec, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+	defer dec.Close()
+
+	for i, ct := range ciphertexts {
+		iv := dec.gcmNonce(7, i)
+
+		aad := make([]byte, 8)
+		binary.BigEndian.PutUint64(aad, 7)
+
+		plaintext, err := dec.aead.Open(nil, iv, ct, aad)
+		require.NoError(t, err)
+
+		var got uint256.Int
+		got.SetBytes(plaintext)
+		require.True(t, got.Eq(values[i]))
+	}
+}
+
+func TestEncryptInputsRejectsTamperedCiphertext(t *testing.T) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract, selector := testContractAndSelector()
+
+	enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+	defer enc.Close()
+
+	ciphertexts, _, err := enc.EncryptInputs(1, uint256.NewInt(1))
+	require.NoError(t, err)
+
+	tampered := append([]byte{}, ciphertexts[0]...)
+	tampered[0] ^= 0xFF
+
+	iv := enc.gcmNonce(1, 0)
+	aad := make([]byte, 8)
+	binary.BigEndian.PutUint64(aad, 1)
+
+	_, err = enc.aead.Open(nil, iv, tampered, aad)
+	require.Error(t, err)
+}
+
+func TestNewEncryptorRequiresNetworkPubKey(t *testing.T) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract, selector := testContractAndSelector()
+
+	_, err = NewEncryptor(userKey, nil, contract, selector)
+	require.Error(t, err)
+}
+
+// TestEncryptInputsWrongNetworkKeyDoesNotDecrypt confirms the session key is
+// bound to networkPubKey: an Encryptor built against the wrong network key
+// (e.g. one an attacker derived from public call data rather than fetching
+// the network's real key) cannot decrypt ciphertexts produced for the real
+// one.
+func TestEncryptInputsWrongNetworkKeyDoesNotDecrypt(t *testing.T) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wrongNetworkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract, selector := testContractAndSelector()
+
+	enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+	defer enc.Close()
+	ciphertexts, _, err := enc.EncryptInputs(1, uint256.NewInt(1))
+	require.NoError(t, err)
+
+	dec, err := NewEncryptor(userKey, &wrongNetworkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+	defer dec.Close()
+
+	iv := dec.gcmNonce(1, 0)
+	aad := make([]byte, 8)
+	binary.BigEndian.PutUint64(aad, 1)
+
+	_, err = dec.aead.Open(nil, iv, ciphertexts[0], aad)
+	require.Error(t, err)
+}
+
+// TestNewEncryptorRejectsConcurrentSessionForSameTuple confirms a second
+// Encryptor can't be opened for a (userKey, networkPubKey, contractAddr,
+// funcSelector) tuple that already has one live: since both would derive
+// the identical AES-GCM key, letting them run concurrently would risk a
+// GCM nonce collision between them. Once the first is closed, the tuple is
+// free again.
+func TestNewEncryptorRejectsConcurrentSessionForSameTuple(t *testing.T) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract, selector := testContractAndSelector()
+
+	enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+
+	_, err = NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.Error(t, err)
+
+	enc.Close()
+
+	again, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+	defer again.Close()
+}
+
+// TestGCMNonceDistinctPerAccountNonceAndIndex confirms the GCM nonce used
+// for each value never repeats across the account nonces and batch indices
+// EncryptInputs can be called with under a single session key - the
+// property that lets the session key be reused safely across calls.
+func TestGCMNonceDistinctPerAccountNonceAndIndex(t *testing.T) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract, selector := testContractAndSelector()
+
+	enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(t, err)
+	defer enc.Close()
+
+	seen := make(map[string]bool)
+	for accountNonce := uint64(0); accountNonce < 5; accountNonce++ {
+		for index := 0; index < 3; index++ {
+			iv := string(enc.gcmNonce(accountNonce, index))
+			require.Falsef(t, seen[iv], "gcm nonce reused for account nonce %d index %d", accountNonce, index)
+			seen[iv] = true
+		}
+	}
+}
+
+// BenchmarkEncryptInputs_PerCallSetup mimics the pre-session behavior:
+// derive a fresh ECDH session key (including the expensive handshake) on
+// every call.
+func BenchmarkEncryptInputs_PerCallSetup(b *testing.B) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(b, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(b, err)
+	contract, selector := testContractAndSelector()
+	values := []*uint256.Int{uint256.NewInt(1), uint256.NewInt(2), uint256.NewInt(3)}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+		require.NoError(b, err)
+		_, _, err = enc.EncryptInputs(uint64(i), values...)
+		require.NoError(b, err)
+		enc.Close()
+	}
+}
+
+func BenchmarkEncryptInputs_CachedSession(b *testing.B) {
+	userKey, err := crypto.GenerateKey()
+	require.NoError(b, err)
+	networkKey, err := crypto.GenerateKey()
+	require.NoError(b, err)
+	contract, selector := testContractAndSelector()
+	values := []*uint256.Int{uint256.NewInt(1), uint256.NewInt(2), uint256.NewInt(3)}
+
+	enc, err := NewEncryptor(userKey, &networkKey.PublicKey, contract, selector)
+	require.NoError(b, err)
+	defer enc.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := enc.EncryptInputs(uint64(i), values...)
+		require.NoError(b, err)
+	}
+}
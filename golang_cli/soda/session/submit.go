@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"golang_cli/signer"
+)
+
+// EthClient is the subset of ethclient.Client that BatchSubmit needs to
+// assign nonces and broadcast signed transactions.
+type EthClient interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// PreparedTx is a transaction ready for signing and broadcast. Result
+// receives the outcome once BatchSubmit has signed and sent it.
+type PreparedTx struct {
+	To       common.Address
+	Value    *big.Int
+	GasLimit uint64
+	GasPrice *big.Int
+	Data     []byte
+	Result   chan<- TxResult
+}
+
+// TxResult is the outcome of submitting a single PreparedTx.
+type TxResult struct {
+	Hash common.Hash
+	Err  error
+}
+
+// Submitter pipelines nonce assignment, concurrent signing and broadcast
+// for many transactions sent from the same account.
+type Submitter struct {
+	Client  EthClient
+	Signer  signer.Signer
+	ChainID *big.Int
+	From    common.Address
+}
+
+// BatchSubmit fetches the account's current pending nonce once, assigns
+// consecutive nonces to txs in order, then signs and broadcasts all of them
+// concurrently. Each tx's outcome is delivered on its own Result channel as
+// soon as it is sent, rather than waiting for the whole batch.
+func (s *Submitter) BatchSubmit(ctx context.Context, txs []PreparedTx) error {
+	startNonce, err := s.Client.PendingNonceAt(ctx, s.From)
+	if err != nil {
+		return fmt.Errorf("fetch pending nonce: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for i, ptx := range txs {
+		wg.Add(1)
+		go func(i int, ptx PreparedTx) {
+			defer wg.Done()
+			s.submitOne(ctx, startNonce+uint64(i), ptx)
+		}(i, ptx)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (s *Submitter) submitOne(ctx context.Context, nonce uint64, ptx PreparedTx) {
+	to := ptx.To
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    ptx.Value,
+		Gas:      ptx.GasLimit,
+		GasPrice: ptx.GasPrice,
+		Data:     ptx.Data,
+	})
+
+	signed, err := s.Signer.SignTx(tx, s.ChainID)
+	if err != nil {
+		ptx.Result <- TxResult{Err: fmt.Errorf("sign tx (nonce %d): %w", nonce, err)}
+		return
+	}
+	if err := s.Client.SendTransaction(ctx, signed); err != nil {
+		ptx.Result <- TxResult{Err: fmt.Errorf("broadcast tx (nonce %d): %w", nonce, err)}
+		return
+	}
+	ptx.Result <- TxResult{Hash: signed.Hash()}
+}
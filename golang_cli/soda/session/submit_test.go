@@ -0,0 +1,104 @@
+package session
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"golang_cli/signer"
+)
+
+func newTestSigner(t *testing.T) signer.Signer {
+	t.Helper()
+	dir := t.TempDir()
+	keyfile := filepath.Join(dir, "key.json")
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("test-password\n"), 0600))
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyjson, err := keystore.EncryptKey(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}, "test-password", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyfile, keyjson, 0600))
+
+	s, err := signer.Open("keystore://"+keyfile, passwordFile)
+	require.NoError(t, err)
+	return s
+}
+
+// fakeEthClient records every broadcast tx, guarded by a mutex since
+// BatchSubmit sends concurrently.
+type fakeEthClient struct {
+	mu        sync.Mutex
+	nonce     uint64
+	broadcast []*types.Transaction
+}
+
+func (c *fakeEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.nonce, nil
+}
+
+func (c *fakeEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.broadcast = append(c.broadcast, tx)
+	return nil
+}
+
+func TestBatchSubmitAssignsConsecutiveNonces(t *testing.T) {
+	s := newTestSigner(t)
+	from, err := s.Address()
+	require.NoError(t, err)
+
+	client := &fakeEthClient{nonce: 5}
+	submitter := &Submitter{
+		Client:  client,
+		Signer:  s,
+		ChainID: big.NewInt(1337),
+		From:    from,
+	}
+
+	const n = 8
+	results := make([]chan TxResult, n)
+	txs := make([]PreparedTx, n)
+	for i := range txs {
+		results[i] = make(chan TxResult, 1)
+		txs[i] = PreparedTx{
+			To:       common.HexToAddress("0x00000000000000000000000000000000000Ca11"),
+			Value:    big.NewInt(0),
+			GasLimit: 21000,
+			GasPrice: big.NewInt(1),
+			Data:     []byte{byte(i)},
+			Result:   results[i],
+		}
+	}
+
+	require.NoError(t, submitter.BatchSubmit(context.Background(), txs))
+
+	for i := range txs {
+		res := <-results[i]
+		require.NoError(t, res.Err)
+		require.NotEqual(t, common.Hash{}, res.Hash)
+	}
+
+	require.Len(t, client.broadcast, n)
+	gotNonces := make(map[uint64]bool, n)
+	for _, tx := range client.broadcast {
+		gotNonces[tx.Nonce()] = true
+	}
+	for i := 0; i < n; i++ {
+		require.Truef(t, gotNonces[5+uint64(i)], "expected nonce %d to have been used", 5+i)
+	}
+}